@@ -0,0 +1,220 @@
+package mat
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+	"github.com/gonum/lapack/lapack64"
+)
+
+// LU returns the LU decomposition of m, with partial pivoting, such that
+// l*u equals m with its rows interchanged according to piv, in LAPACK's
+// convention: for i = 0, 1, ..., n-1 in turn, row i was swapped with row
+// piv[i] (piv[i] == i means no swap at that step).
+func LU(m Matrix) (l, u Matrix, piv []int) {
+	if m.rows != m.cols {
+		panicf("can't compute the LU decomposition of a non-square %dx%d matrix", m.rows, m.cols)
+	}
+
+	a := m.Clone()
+	ipiv := make([]int, m.rows)
+	ok := lapack64.Getrf(generalFromMat(a), ipiv)
+	if !ok {
+		panicf("matrix is singular, can't compute its LU decomposition")
+	}
+
+	l, u = New(m.rows, m.cols), New(m.rows, m.cols)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			switch {
+			case j < i:
+				l.set(i, j, a.at(i, j))
+			case j == i:
+				l.set(i, j, 1)
+				u.set(i, j, a.at(i, j))
+			default:
+				u.set(i, j, a.at(i, j))
+			}
+		}
+	}
+	return l, u, ipiv
+}
+
+// QR returns the QR decomposition of m, such that q*r equals m and q is
+// orthogonal.
+func QR(m Matrix) (q, r Matrix) {
+	a := m.Clone()
+	tau := make([]float64, min(m.rows, m.cols))
+
+	work := make([]float64, 1)
+	lapack64.Geqrf(generalFromMat(a), tau, work, -1)
+	work = make([]float64, int(work[0]))
+	lapack64.Geqrf(generalFromMat(a), tau, work, len(work))
+
+	r = New(m.rows, m.cols)
+	for i := 0; i < m.rows; i++ {
+		for j := i; j < m.cols; j++ {
+			r.set(i, j, a.at(i, j))
+		}
+	}
+
+	// lapack64 has no Orgqr to form q explicitly, so build it by applying
+	// the Householder reflectors Geqrf left in a to the identity: q*I = q.
+	q = New(m.rows, m.rows)
+	for i := 0; i < m.rows; i++ {
+		q.set(i, i, 1)
+	}
+	work = make([]float64, 1)
+	lapack64.Ormqr(blas.Left, blas.NoTrans, generalFromMat(a), tau, generalFromMat(q), work, -1)
+	work = make([]float64, int(work[0]))
+	lapack64.Ormqr(blas.Left, blas.NoTrans, generalFromMat(a), tau, generalFromMat(q), work, len(work))
+
+	return q, r
+}
+
+// Cholesky returns the lower-triangular Cholesky factor l of the symmetric,
+// positive-definite matrix m, such that l*l^T equals m.
+func Cholesky(m SymmetricMatrix) TriangularMatrix {
+	g := m.general()
+	t, ok := lapack64.Potrf(g)
+	if !ok {
+		panicf("matrix is not positive-definite, can't compute its Cholesky factor")
+	}
+
+	// t.Uplo is always blas.Upper (SymmetricMatrix only ever stores its
+	// upper triangle), so Potrf only wrote the upper factor u such that
+	// u^T*u equals m, leaving the lower triangle of t.Data untouched. Its
+	// transpose u^T is the lower-triangular l we want: l*l^T = u^T*u = m.
+	u := newMat(m.n, m.n, t.Data)
+	return PackFromMatrix(u.T(), blas.Lower)
+}
+
+// SVD returns the singular value decomposition of m, such that
+// u*Diag(s)*v^T equals m.
+func SVD(m Matrix) (u, s, v Matrix) {
+	a := m.Clone()
+	k := min(m.rows, m.cols)
+	sv := make([]float64, k)
+	uMat := New(m.rows, m.rows)
+	vtMat := New(m.cols, m.cols)
+
+	work := make([]float64, 1)
+	lapack64.Gesvd(lapack.SVDAll, lapack.SVDAll, generalFromMat(a), generalFromMat(uMat), generalFromMat(vtMat), sv, work, -1)
+	work = make([]float64, int(work[0]))
+	lapack64.Gesvd(lapack.SVDAll, lapack.SVDAll, generalFromMat(a), generalFromMat(uMat), generalFromMat(vtMat), sv, work, len(work))
+
+	s = New(m.rows, m.cols)
+	for i, sigma := range sv {
+		s.set(i, i, sigma)
+	}
+	return uMat, s, vtMat.T()
+}
+
+// Solve returns the matrix x that solves a*x = b.
+func Solve(a, b Matrix) Matrix {
+	if a.rows != a.cols {
+		panicf("can't solve a linear system with a non-square %dx%d coefficient matrix", a.rows, a.cols)
+	}
+
+	lu := a.Clone()
+	piv := make([]int, a.rows)
+	ok := lapack64.Getrf(generalFromMat(lu), piv)
+	if !ok {
+		panicf("matrix is singular, can't solve the linear system")
+	}
+
+	x := b.Clone()
+	lapack64.Getrs(blas.NoTrans, generalFromMat(lu), generalFromMat(x), piv)
+	return x
+}
+
+// Inverse returns the inverse of m.
+func Inverse(m Matrix) Matrix {
+	if m.rows != m.cols {
+		panicf("can't invert a non-square %dx%d matrix", m.rows, m.cols)
+	}
+
+	a := m.Clone()
+	piv := make([]int, m.rows)
+	ok := lapack64.Getrf(generalFromMat(a), piv)
+	if !ok {
+		panicf("matrix is singular, can't invert it")
+	}
+	work := make([]float64, 1)
+	lapack64.Getri(generalFromMat(a), piv, work, -1)
+	work = make([]float64, int(work[0]))
+	lapack64.Getri(generalFromMat(a), piv, work, len(work))
+	return a
+}
+
+// Det returns the determinant of m, computed from its LU decomposition as
+// the product of the diagonal of u, with a sign flip per row swap in piv.
+func Det(m Matrix) float64 {
+	if m.rows != m.cols {
+		panicf("can't compute the determinant of a non-square %dx%d matrix", m.rows, m.cols)
+	}
+
+	_, u, piv := LU(m)
+	det := 1.0
+	for i := 0; i < u.rows; i++ {
+		det *= u.at(i, i)
+	}
+	for i, p := range piv {
+		if p != i {
+			det = -det
+		}
+	}
+	return det
+}
+
+// Rank returns the numerical rank of m: the number of singular values
+// larger than a tolerance proportional to the largest one.
+func Rank(m Matrix) int {
+	_, s, _ := SVD(m)
+	k := min(s.rows, s.cols)
+	if k == 0 {
+		return 0
+	}
+
+	tol := float64(max(m.rows, m.cols)) * s.at(0, 0) * 2.22e-16
+	rank := 0
+	for i := 0; i < k; i++ {
+		if s.at(i, i) > tol {
+			rank++
+		}
+	}
+	return rank
+}
+
+// Cond returns an estimate of the 2-norm condition number of m, the ratio
+// of its largest to its smallest singular value. A large condition number
+// means m is close to singular and solutions computed against it will be
+// numerically unstable.
+func Cond(m Matrix) float64 {
+	_, s, _ := SVD(m)
+	k := min(s.rows, s.cols)
+	if k == 0 {
+		return math.Inf(1)
+	}
+
+	smallest := s.at(k-1, k-1)
+	if smallest == 0 {
+		return math.Inf(1)
+	}
+	return s.at(0, 0) / smallest
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,132 @@
+package mat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	a := FromSlice(2, 2, []float64{
+		2, 1,
+		1, 3,
+	})
+	b := FromSlice(2, 1, []float64{3, 5})
+
+	x := Solve(a, b)
+	want := FromSlice(2, 1, []float64{0.8, 1.4})
+	for i := 0; i < 2; i++ {
+		if math.Abs(x.At(i, 0)-want.At(i, 0)) > 1e-9 {
+			t.Errorf("expected:\n%v\ngot:\n%v\n", want, x)
+			break
+		}
+	}
+}
+
+func TestInverse(t *testing.T) {
+	a := FromSlice(2, 2, []float64{
+		4, 7,
+		2, 6,
+	})
+
+	inv := Inverse(a)
+	id := Product(a, inv)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			want := 0.0
+			if i == j {
+				want = 1.0
+			}
+			if math.Abs(id.At(i, j)-want) > 1e-9 {
+				t.Errorf("expected a*inv(a) to be the identity, got:\n%v\n", id)
+			}
+		}
+	}
+}
+
+func TestDet(t *testing.T) {
+	a := FromSlice(2, 2, []float64{
+		4, 7,
+		2, 6,
+	})
+
+	if got, want := Det(a), 10.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected determinant %v, got %v", want, got)
+	}
+}
+
+func TestQR(t *testing.T) {
+	m := FromSlice(3, 2, []float64{
+		12, -51,
+		6, 167,
+		-4, 24,
+	})
+
+	q, r := QR(m)
+	got := Product(q, r)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 2; j++ {
+			if math.Abs(got.At(i, j)-m.At(i, j)) > 1e-9 {
+				t.Errorf("expected q*r to equal:\n%v\ngot:\n%v\n", m, got)
+			}
+		}
+	}
+
+	id := Product(q.T(), q)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			want := 0.0
+			if i == j {
+				want = 1.0
+			}
+			if math.Abs(id.At(i, j)-want) > 1e-9 {
+				t.Errorf("expected q to be orthogonal, got q^T*q:\n%v\n", id)
+			}
+		}
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	m := FromSlice(2, 2, []float64{
+		4, 2,
+		2, 3,
+	})
+
+	l := Cholesky(PackSymmetric(m)).Unpack()
+	got := Product(l, l.T())
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if math.Abs(got.At(i, j)-m.At(i, j)) > 1e-9 {
+				t.Errorf("expected l*l^T to equal:\n%v\ngot:\n%v\n", m, got)
+			}
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	full := FromSlice(2, 2, []float64{
+		1, 0,
+		0, 1,
+	})
+	if got, want := Rank(full), 2; got != want {
+		t.Errorf("expected rank %d, got %d", want, got)
+	}
+
+	singular := FromSlice(2, 2, []float64{
+		1, 2,
+		2, 4,
+	})
+	if got, want := Rank(singular), 1; got != want {
+		t.Errorf("expected rank %d, got %d", want, got)
+	}
+}
+
+func TestCond(t *testing.T) {
+	m := FromSlice(2, 2, []float64{
+		4, 0,
+		0, 1,
+	})
+
+	if got, want := Cond(m), 4.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected condition number %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,29 @@
+package mat
+
+import "testing"
+
+func benchmarkProductGPU(n int, b *testing.B) {
+	a := FromFunc(n, n, func(i, j int) float64 { return float64(i + j) })
+	m := FromFunc(n, n, func(i, j int) float64 { return float64(i - j) })
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ProductGPU(a, m)
+	}
+}
+
+func benchmarkBlasProduct(n int, b *testing.B) {
+	a := FromFunc(n, n, func(i, j int) float64 { return float64(i + j) })
+	m := FromFunc(n, n, func(i, j int) float64 { return float64(i - j) })
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BlasProduct(a, m)
+	}
+}
+
+func BenchmarkProductGPU1024(b *testing.B)  { benchmarkProductGPU(1024, b) }
+func BenchmarkBlasProduct1024(b *testing.B) { benchmarkBlasProduct(1024, b) }
+
+func BenchmarkProductGPU2048(b *testing.B)  { benchmarkProductGPU(2048, b) }
+func BenchmarkBlasProduct2048(b *testing.B) { benchmarkBlasProduct(2048, b) }
@@ -0,0 +1,129 @@
+// +build cuda
+
+package mat
+
+/*
+#cgo LDFLAGS: -lcublas -lcudart
+#include <cublas_v2.h>
+#include <cuda_runtime.h>
+
+static cublasStatus_t dgemm(cublasHandle_t handle, int m, int n, int k,
+		const double *alpha, const double *a, const double *b,
+		const double *beta, double *c) {
+	// a is m x k, b is k x n, c is m x n, all row-major; cuBLAS is
+	// column-major so we compute c^T = b^T * a^T instead.
+	return cublasDgemm(handle, CUBLAS_OP_N, CUBLAS_OP_N, n, m, k,
+			alpha, b, n, a, k, beta, c, n);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// device holds the process-wide cuBLAS context and a pool of device buffers
+// keyed by their size in floats, so that repeated products don't pay for a
+// cudaMalloc/cudaFree (or a re-JIT of the cuBLAS kernels) on every call.
+//
+// Buffers are plain Runtime-API device pointers (unsafe.Pointer, matching
+// C's void*), not Driver-API CUdeviceptr handles: this file only links
+// against cuBLAS and the CUDA Runtime API, and the two pointer kinds aren't
+// interchangeable.
+type device struct {
+	handle  C.cublasHandle_t
+	mu      sync.Mutex
+	buffers map[int][]unsafe.Pointer
+}
+
+var (
+	gpu     device
+	gpuOnce sync.Once
+	gpuErr  error
+)
+
+func initGPU() {
+	gpuOnce.Do(func() {
+		if st := C.cublasCreate(&gpu.handle); st != C.CUBLAS_STATUS_SUCCESS {
+			gpuErr = fmt.Errorf("mat: cublasCreate failed: %d", st)
+			return
+		}
+		gpu.buffers = make(map[int][]unsafe.Pointer)
+	})
+}
+
+// GPUAvailable reports whether a CUDA device is present and usable.
+func GPUAvailable() bool {
+	initGPU()
+	return gpuErr == nil
+}
+
+// alloc returns a device buffer with room for n float64s, reusing one from
+// the pool when possible.
+func (d *device) alloc(n int) unsafe.Pointer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if bufs := d.buffers[n]; len(bufs) > 0 {
+		buf := bufs[len(bufs)-1]
+		d.buffers[n] = bufs[:len(bufs)-1]
+		return buf
+	}
+
+	var ptr unsafe.Pointer
+	C.cudaMalloc(&ptr, C.size_t(n)*C.sizeof_double)
+	return ptr
+}
+
+func (d *device) free(n int, ptr unsafe.Pointer) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buffers[n] = append(d.buffers[n], ptr)
+}
+
+// ProductGPU returns the product of two matrices computed on the GPU via
+// cuBLAS's DGEMM, falling back to BlasProduct if no device is available.
+func ProductGPU(a, b Matrix) Matrix {
+	if a.cols != b.rows {
+		panicf("can't compute product of matrices with dimensions %dx%d and %dx%d",
+			a.rows, a.cols, b.rows, b.cols)
+	}
+	if !GPUAvailable() {
+		return BlasProduct(a, b)
+	}
+
+	m, k, n := a.rows, a.cols, b.cols
+
+	da := gpu.alloc(m * k)
+	db := gpu.alloc(k * n)
+	dc := gpu.alloc(m * n)
+	defer gpu.free(m*k, da)
+	defer gpu.free(k*n, db)
+	defer gpu.free(m*n, dc)
+
+	// a and b may be strided or transposed views (e.g. from SliceRows,
+	// SliceCols or T), so their logical contents aren't necessarily
+	// contiguous in a.data/b.data; ToSlice materializes a row-major copy
+	// when needed, which is what the device buffers expect.
+	ah, bh := a.ToSlice(), b.ToSlice()
+	C.cudaMemcpy(da, unsafe.Pointer(&ah[0]),
+		C.size_t(m*k)*C.sizeof_double, C.cudaMemcpyHostToDevice)
+	C.cudaMemcpy(db, unsafe.Pointer(&bh[0]),
+		C.size_t(k*n)*C.sizeof_double, C.cudaMemcpyHostToDevice)
+
+	alpha, beta := C.double(1), C.double(0)
+	C.dgemm(gpu.handle, C.int(m), C.int(n), C.int(k),
+		&alpha,
+		(*C.double)(da),
+		(*C.double)(db),
+		&beta,
+		(*C.double)(dc))
+
+	c := New(m, n)
+	C.cudaMemcpy(unsafe.Pointer(&c.data[0]), dc,
+		C.size_t(m*n)*C.sizeof_double, C.cudaMemcpyDeviceToHost)
+
+	return c
+}
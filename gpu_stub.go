@@ -0,0 +1,13 @@
+// +build !cuda
+
+package mat
+
+// GPUAvailable reports whether a CUDA device is present and usable.
+// This build has no cuBLAS/OpenCL support, so it always returns false.
+func GPUAvailable() bool { return false }
+
+// ProductGPU returns the product of two matrices. Without a "cuda" build
+// tag there is no device to dispatch to, so it falls back to BlasProduct.
+func ProductGPU(a, b Matrix) Matrix {
+	return BlasProduct(a, b)
+}
@@ -13,14 +13,30 @@ import (
 )
 
 // Matrix is optimized for dense matrices.
+//
+// Internally a Matrix is a view over a backing array: stride is the number
+// of elements between the start of consecutive logical rows (or columns, if
+// transposed is set) in data, and offset is where the view starts. Slicing
+// and transposing return new views over the same backing array rather than
+// copying it; writes always go through Clone first, so the backing array
+// itself is never mutated once it's shared between two Matrix values.
 type Matrix struct {
 	rows, cols int
+	stride     int
+	offset     int
+	transposed bool
 	data       []float64
 }
 
+// newMat returns a new, contiguous Matrix backed by data, which must hold
+// exactly rows*cols elements in row-major order.
+func newMat(rows, cols int, data []float64) Matrix {
+	return Matrix{rows: rows, cols: cols, stride: cols, data: data}
+}
+
 // New returns a new matrix with the given dimensions and where all cells are zero.
 func New(rows, cols int) Matrix {
-	return Matrix{rows, cols, make([]float64, rows*cols)}
+	return newMat(rows, cols, make([]float64, rows*cols))
 }
 
 // FromSlice returns a new Matrix with the contents of the given slice.
@@ -31,14 +47,20 @@ func FromSlice(rows, cols int, data []float64) Matrix {
 
 	c := make([]float64, len(data))
 	copy(c, data)
-	return Matrix{rows, cols, c}
+	return newMat(rows, cols, c)
 }
 
-func (m Matrix) ToSlice() []float64 { return m.data }
+// ToSlice returns the contents of the matrix as a flat, row-major slice.
+func (m Matrix) ToSlice() []float64 {
+	if !m.transposed && m.stride == m.cols && m.offset == 0 {
+		return m.data[:m.rows*m.cols]
+	}
+	return m.Materialize().data
+}
 
 // FromFunc returns a new Matrix with the contents initialized by calling f.
 func FromFunc(rows, cols int, f func(i, j int) float64) Matrix {
-	m := Matrix{rows, cols, make([]float64, rows*cols)}
+	m := New(rows, cols)
 	for i := 0; i < rows; i++ {
 		for j := 0; j < cols; j++ {
 			m.set(i, j, f(i, j))
@@ -77,21 +99,39 @@ func (m Matrix) At(i, j int) float64 {
 	return m.at(i, j)
 }
 
-func (m Matrix) at(i, j int) float64 { return m.data[m.cols*i+j] }
+func (m Matrix) at(i, j int) float64 {
+	if m.transposed {
+		return m.data[m.offset+j*m.stride+i]
+	}
+	return m.data[m.offset+i*m.stride+j]
+}
 
 // Clone returns a copy of the current Matrix.
-func (m Matrix) Clone() Matrix {
+func (m Matrix) Clone() Matrix { return m.Materialize() }
+
+// Materialize returns a contiguous copy of m: a Matrix that owns its own
+// backing array and no longer shares storage (or a stride) with any view
+// it was sliced or transposed from.
+func (m Matrix) Materialize() Matrix {
 	c := New(m.rows, m.cols)
-	copy(c.data, m.data)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			c.set(i, j, m.at(i, j))
+		}
+	}
 	return c
 }
 
-func (m Matrix) Apply(f func(i, j int) float64) {
-	for i := 0; i < m.rows; i++ {
-		for j := 0; j < m.cols; j++ {
-			m.set(i, j, f(i, j))
+// Apply returns a new matrix where the cell at every position has been
+// replaced by the result of calling f with that position.
+func (m Matrix) Apply(f func(i, j int) float64) Matrix {
+	r := m.Clone()
+	for i := 0; i < r.rows; i++ {
+		for j := 0; j < r.cols; j++ {
+			r.set(i, j, f(i, j))
 		}
 	}
+	return r
 }
 
 // Set sets the value of the cell at the given position.
@@ -103,7 +143,13 @@ func (m Matrix) Set(i, j int, x float64) Matrix {
 	return r
 }
 
-func (m *Matrix) set(i, j int, x float64) { m.data[m.cols*i+j] = x }
+func (m *Matrix) set(i, j int, x float64) {
+	if m.transposed {
+		m.data[m.offset+j*m.stride+i] = x
+		return
+	}
+	m.data[m.offset+i*m.stride+j] = x
+}
 
 // Scale multiplies the receiver matrix by the given scalar.
 func (m Matrix) Scale(x float64) Matrix {
@@ -118,7 +164,7 @@ func (m Matrix) AddScalar(x float64) Matrix {
 // Map returns a new Matrix where each value is the result of calling f with the
 // value of that position in the original matrix.
 func Map(f func(float64) float64, m Matrix) Matrix {
-	r := m.Clone()
+	r := m.Materialize()
 	for i, v := range r.data {
 		r.data[i] = f(v)
 	}
@@ -146,11 +192,13 @@ func ConcatenateCols(ms ...Matrix) Matrix {
 	data := make([]float64, 0, rows*cols)
 	for i := 0; i < rows; i++ {
 		for _, m := range ms {
-			data = append(data, m.data[i*m.cols:(i+1)*m.cols]...)
+			for j := 0; j < m.cols; j++ {
+				data = append(data, m.at(i, j))
+			}
 		}
 	}
 
-	return Matrix{rows, cols, data}
+	return newMat(rows, cols, data)
 }
 
 // ConcatenateRows returns a matrix that contains the values of all of the given
@@ -173,36 +221,52 @@ func ConcatenateRows(ms ...Matrix) Matrix {
 
 	data := make([]float64, 0, rows*cols)
 	for _, m := range ms {
-		data = append(data, m.data...)
+		for i := 0; i < m.rows; i++ {
+			for j := 0; j < m.cols; j++ {
+				data = append(data, m.at(i, j))
+			}
+		}
 	}
 
-	return Matrix{rows, cols, data}
+	return newMat(rows, cols, data)
 }
 
-// SliceCols returns a new matrix that contains only the columns in between
+// SliceCols returns a matrix that contains only the columns in between
 // from and to, without including to. Similar to slice[from:to].
+// The result is a view over the same backing array as m: it shares storage
+// until one of them is written to.
 func (m Matrix) SliceCols(from, to int) Matrix {
 	if from < 0 || to > m.cols || to < from {
 		panic("bad row numbers")
 	}
 
-	data := make([]float64, 0, m.rows*(to-from))
-	for i := 0; i < m.rows; i++ {
-		data = append(data, m.data[i*m.cols+from:i*m.cols+to]...)
+	r := m
+	r.cols = to - from
+	if m.transposed {
+		r.offset = m.offset + from*m.stride
+	} else {
+		r.offset = m.offset + from
 	}
-	return Matrix{m.rows, to - from, data}
+	return r
 }
 
-// SliceRows returns a new matrix that contains only the rows in between
+// SliceRows returns a matrix that contains only the rows in between
 // from and to, without including to. Similar to slice[from:to].
+// The result is a view over the same backing array as m: it shares storage
+// until one of them is written to.
 func (m Matrix) SliceRows(from, to int) Matrix {
 	if from < 0 || to > m.rows || to < from {
 		panic("bad row numbers")
 	}
 
-	data := make([]float64, m.cols*(to-from))
-	copy(data, m.data[from*m.cols:to*m.cols])
-	return Matrix{to - from, m.cols, data}
+	r := m
+	r.rows = to - from
+	if m.transposed {
+		r.offset = m.offset + from
+	} else {
+		r.offset = m.offset + from*m.stride
+	}
+	return r
 }
 
 // Sum returns the sum of all of the elements in the matrix.
@@ -218,8 +282,10 @@ func Sum(m Matrix) float64 { return m.Sum() }
 //   m.Reduce(0, func(x, cum float64) float64 {return x+cum})
 func (m Matrix) Reduce(zero float64, f func(x, cum float64) float64) float64 {
 	cum := zero
-	for _, x := range m.data {
-		cum = f(x, cum)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			cum = f(m.at(i, j), cum)
+		}
 	}
 	return cum
 }
@@ -231,24 +297,22 @@ func (m Matrix) FilterRows(f func(i int) bool) Matrix {
 	var data []float64
 	for i := 0; i < m.rows; i++ {
 		if f(i) {
-			data = append(data, m.data[i*cols:(i+1)*m.cols]...)
+			for j := 0; j < cols; j++ {
+				data = append(data, m.at(i, j))
+			}
 			rows++
 		}
 	}
-	return Matrix{rows, cols, data}
+	return newMat(rows, cols, data)
 }
 
-// T returns the transposed matrix.
+// T returns the transposed matrix, as a view over the same backing array as
+// m: it shares storage with m until one of them is written to.
 func (m Matrix) T() Matrix {
-	t := Matrix{m.cols, m.rows, make([]float64, len(m.data))}
-
-	for i := 0; i < m.rows; i++ {
-		for j := 0; j < m.cols; j++ {
-			t.set(j, i, m.at(i, j))
-		}
-	}
-
-	return t
+	r := m
+	r.rows, r.cols = m.cols, m.rows
+	r.transposed = !m.transposed
+	return r
 }
 
 // Equals returns whether two matrices are identical.
@@ -257,9 +321,11 @@ func Equals(a, b Matrix) bool {
 		return false
 	}
 
-	for i, v := range a.data {
-		if v != b.data[i] {
-			return false
+	for i := 0; i < a.rows; i++ {
+		for j := 0; j < a.cols; j++ {
+			if a.at(i, j) != b.at(i, j) {
+				return false
+			}
 		}
 	}
 	return true
@@ -314,17 +380,33 @@ func ParallelProduct(a, b Matrix) Matrix {
 // BlasProduct returns the product of two matrices performed with blas.
 func BlasProduct(a, b Matrix) Matrix {
 	c := New(a.Rows(), b.Cols())
-	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1.0, generalFromMat(a), generalFromMat(b), 0.0, generalFromMat(c))
+	blas64.Gemm(transOf(a), transOf(b), 1.0, generalFromMat(a), generalFromMat(b), 0.0, generalFromMat(c))
 	return c
 }
 
+// generalFromMat returns the blas64.General view of m's backing array,
+// honoring its stride and transposed flag so that strided slices and
+// transposed views can be passed to BLAS without copying.
 func generalFromMat(m Matrix) blas64.General {
+	rows, cols := m.rows, m.cols
+	if m.transposed {
+		rows, cols = cols, rows
+	}
 	return blas64.General{
-		Rows:   m.Rows(),
-		Cols:   m.Cols(),
-		Stride: m.Cols(),
-		Data:   m.ToSlice(),
+		Rows:   rows,
+		Cols:   cols,
+		Stride: m.stride,
+		Data:   m.data[m.offset:],
+	}
+}
+
+// transOf returns the blas.Transpose flag BLAS needs to interpret
+// generalFromMat(m) as m.
+func transOf(m Matrix) blas.Transpose {
+	if m.transposed {
+		return blas.Trans
 	}
+	return blas.NoTrans
 }
 
 // Dot returns the dot product of two matrices.
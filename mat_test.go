@@ -103,7 +103,7 @@ func TestProductGPU(t *testing.T) {
 	}
 
 	for _, tc := range tt {
-		if p := Product(tc.a, tc.b); !Equals(p, tc.c) {
+		if p := ProductGPU(tc.a, tc.b); !Equals(p, tc.c) {
 			t.Errorf("expected result:\n%v\ngot:\n%v\n", tc.c, p)
 		}
 	}
@@ -0,0 +1,84 @@
+package optimize
+
+import "github.com/campoy/mat"
+
+// lbfgsPair is one (s, y) correction pair kept in LBFGS's history:
+// s = x_{k+1} - x_k and y = grad_{k+1} - grad_k.
+type lbfgsPair struct {
+	s, y mat.Matrix
+}
+
+// LBFGS minimizes an ObjectiveFunc, approximating the inverse Hessian from
+// the last opts.History (s, y) pairs via the two-loop recursion instead of
+// forming it explicitly.
+type LBFGS struct{}
+
+func (LBFGS) Minimize(obj ObjectiveFunc, init mat.Matrix, opts Options) Result {
+	opts = opts.withDefaults()
+
+	var history []lbfgsPair
+	x := init
+	loss, grad := obj(x)
+
+	iter := 0
+	for ; iter < opts.MaxIterations; iter++ {
+		if norm(grad) < opts.Tolerance {
+			break
+		}
+
+		dir := lbfgsDirection(grad, history)
+		if mat.Dot(grad, dir).Sum() >= 0 {
+			dir = grad.Scale(-1)
+		}
+
+		step := opts.step(obj, x, grad, dir, loss)
+		if step == 0 {
+			break
+		}
+
+		newX := mat.Plus(x, dir.Scale(step))
+		newLoss, newGrad := obj(newX)
+
+		history = append(history, lbfgsPair{
+			s: mat.Minus(newX, x),
+			y: mat.Minus(newGrad, grad),
+		})
+		if len(history) > opts.History {
+			history = history[1:]
+		}
+
+		x, loss, grad = newX, newLoss, newGrad
+	}
+	return Result{X: x, Loss: loss, Gradient: grad, Iterations: iter}
+}
+
+// lbfgsDirection computes the approximate Newton direction -H*grad from
+// history via the standard two-loop recursion, without ever forming H.
+func lbfgsDirection(grad mat.Matrix, history []lbfgsPair) mat.Matrix {
+	n := len(history)
+	rho := make([]float64, n)
+	alpha := make([]float64, n)
+
+	q := grad
+	for i := n - 1; i >= 0; i-- {
+		p := history[i]
+		rho[i] = 1 / mat.Dot(p.y, p.s).Sum()
+		alpha[i] = rho[i] * mat.Dot(p.s, q).Sum()
+		q = mat.Minus(q, p.y.Scale(alpha[i]))
+	}
+
+	r := q
+	if n > 0 {
+		last := history[n-1]
+		gamma := mat.Dot(last.s, last.y).Sum() / mat.Dot(last.y, last.y).Sum()
+		r = q.Scale(gamma)
+	}
+
+	for i := 0; i < n; i++ {
+		p := history[i]
+		beta := rho[i] * mat.Dot(p.y, r).Sum()
+		r = mat.Plus(r, p.s.Scale(alpha[i]-beta))
+	}
+
+	return r.Scale(-1)
+}
@@ -0,0 +1,51 @@
+package optimize
+
+import "github.com/campoy/mat"
+
+// maxBacktracks bounds how many times a Linesearcher may halve (or shrink)
+// its step before giving up and returning whatever it has.
+const maxBacktracks = 50
+
+// Linesearcher picks a step length along dir starting from x, given the
+// current loss and gradient at x.
+type Linesearcher interface {
+	Search(obj ObjectiveFunc, x, grad, dir mat.Matrix, loss, step float64) float64
+}
+
+// BacktrackingArmijo is a Linesearcher that starts at an initial step and
+// shrinks it by Rho until the Armijo sufficient-decrease condition holds.
+type BacktrackingArmijo struct {
+	// C1 is the sufficient-decrease constant; a smaller C1 accepts steps
+	// more readily. Typical values are around 1e-4.
+	C1 float64
+	// Rho is the shrink factor applied to the step on every backtrack.
+	Rho float64
+}
+
+// NewBacktrackingArmijo returns a BacktrackingArmijo with the usual
+// defaults of C1=1e-4 and Rho=0.5.
+func NewBacktrackingArmijo() BacktrackingArmijo {
+	return BacktrackingArmijo{C1: 1e-4, Rho: 0.5}
+}
+
+// Search returns the largest step of the form step*Rho^k that satisfies the
+// Armijo condition loss(x+step*dir) <= loss + C1*step*(grad.dir).
+// It returns 0 if dir is not a descent direction, i.e. grad.dir >= 0.
+// If none of the first maxBacktracks candidates satisfies the condition, it
+// returns step*Rho^maxBacktracks without checking it.
+func (ls BacktrackingArmijo) Search(obj ObjectiveFunc, x, grad, dir mat.Matrix, loss, step float64) float64 {
+	gd := mat.Dot(grad, dir).Sum()
+	if gd >= 0 {
+		return 0
+	}
+
+	for i := 0; i < maxBacktracks; i++ {
+		trial := mat.Plus(x, dir.Scale(step))
+		newLoss, _ := obj(trial)
+		if newLoss <= loss+ls.C1*step*gd {
+			return step
+		}
+		step *= ls.Rho
+	}
+	return step
+}
@@ -0,0 +1,184 @@
+// The optimize package provides gradient-based solvers that treat a
+// mat.Matrix as the vector of parameters being optimized.
+package optimize
+
+import (
+	"math"
+
+	"github.com/campoy/mat"
+)
+
+// ObjectiveFunc computes the loss and gradient of the function being
+// minimized at theta.
+type ObjectiveFunc func(theta mat.Matrix) (loss float64, grad mat.Matrix)
+
+// Options configures a solver. Any field left at its zero value is
+// replaced by a sensible default; see withDefaults.
+type Options struct {
+	// MaxIterations bounds how many steps a solver will take. Defaults to 100.
+	MaxIterations int
+	// Tolerance stops the solver once the gradient's norm drops below it.
+	// Defaults to 1e-6.
+	Tolerance float64
+	// StepSize is the learning rate, or the initial step handed to the
+	// Linesearcher if one is set. Defaults to 1e-3.
+	StepSize float64
+	// Linesearcher picks the actual step along the descent direction on
+	// every iteration. If nil, StepSize is used unconditionally.
+	// Only GradientDescent and LBFGS consult it; Momentum and Adam always
+	// use StepSize.
+	Linesearcher Linesearcher
+
+	// Momentum is the decay of the velocity term used by Momentum. Defaults to 0.9.
+	Momentum float64
+
+	// Beta1 and Beta2 are Adam's exponential decay rates for its first and
+	// second moment estimates. They default to 0.9 and 0.999.
+	Beta1, Beta2 float64
+	// Epsilon guards Adam's update against division by zero. Defaults to 1e-8.
+	Epsilon float64
+
+	// History is the number of (s, y) pairs LBFGS keeps in its ring
+	// buffer. Defaults to 10.
+	History int
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxIterations == 0 {
+		o.MaxIterations = 100
+	}
+	if o.Tolerance == 0 {
+		o.Tolerance = 1e-6
+	}
+	if o.StepSize == 0 {
+		o.StepSize = 1e-3
+	}
+	if o.Momentum == 0 {
+		o.Momentum = 0.9
+	}
+	if o.Beta1 == 0 {
+		o.Beta1 = 0.9
+	}
+	if o.Beta2 == 0 {
+		o.Beta2 = 0.999
+	}
+	if o.Epsilon == 0 {
+		o.Epsilon = 1e-8
+	}
+	if o.History == 0 {
+		o.History = 10
+	}
+	return o
+}
+
+// Result is what every solver's Minimize returns.
+type Result struct {
+	X          mat.Matrix
+	Loss       float64
+	Gradient   mat.Matrix
+	Iterations int
+}
+
+// Solver minimizes an ObjectiveFunc starting from an initial point.
+type Solver interface {
+	Minimize(obj ObjectiveFunc, init mat.Matrix, opts Options) Result
+}
+
+func norm(m mat.Matrix) float64 {
+	return math.Sqrt(mat.Dot(m, m).Sum())
+}
+
+// step returns the step length to take along dir: the Linesearcher's
+// choice if one is configured, or opts.StepSize otherwise.
+func (o Options) step(obj ObjectiveFunc, x, grad, dir mat.Matrix, loss float64) float64 {
+	if o.Linesearcher == nil {
+		return o.StepSize
+	}
+	return o.Linesearcher.Search(obj, x, grad, dir, loss, o.StepSize)
+}
+
+// GradientDescent minimizes an ObjectiveFunc by repeatedly stepping along
+// the negative gradient.
+type GradientDescent struct{}
+
+func (GradientDescent) Minimize(obj ObjectiveFunc, init mat.Matrix, opts Options) Result {
+	opts = opts.withDefaults()
+
+	x := init
+	loss, grad := obj(x)
+
+	iter := 0
+	for ; iter < opts.MaxIterations; iter++ {
+		if norm(grad) < opts.Tolerance {
+			break
+		}
+
+		dir := grad.Scale(-1)
+		step := opts.step(obj, x, grad, dir, loss)
+		if step == 0 {
+			break
+		}
+
+		x = mat.Plus(x, dir.Scale(step))
+		loss, grad = obj(x)
+	}
+	return Result{X: x, Loss: loss, Gradient: grad, Iterations: iter}
+}
+
+// Momentum minimizes an ObjectiveFunc using gradient descent with a
+// velocity term that accumulates across iterations.
+type Momentum struct{}
+
+func (Momentum) Minimize(obj ObjectiveFunc, init mat.Matrix, opts Options) Result {
+	opts = opts.withDefaults()
+
+	x := init
+	loss, grad := obj(x)
+	v := mat.New(init.Rows(), init.Cols())
+
+	iter := 0
+	for ; iter < opts.MaxIterations; iter++ {
+		if norm(grad) < opts.Tolerance {
+			break
+		}
+
+		v = mat.Minus(v.Scale(opts.Momentum), grad.Scale(opts.StepSize))
+		x = mat.Plus(x, v)
+		loss, grad = obj(x)
+	}
+	return Result{X: x, Loss: loss, Gradient: grad, Iterations: iter}
+}
+
+// Adam minimizes an ObjectiveFunc, adapting the step of every parameter
+// from running estimates of the first and second moments of its gradient.
+type Adam struct{}
+
+func (Adam) Minimize(obj ObjectiveFunc, init mat.Matrix, opts Options) Result {
+	opts = opts.withDefaults()
+
+	x := init
+	loss, grad := obj(x)
+	m := mat.New(init.Rows(), init.Cols())
+	v := mat.New(init.Rows(), init.Cols())
+
+	iter := 0
+	for ; iter < opts.MaxIterations; iter++ {
+		if norm(grad) < opts.Tolerance {
+			break
+		}
+
+		t := float64(iter + 1)
+		m = mat.Plus(m.Scale(opts.Beta1), grad.Scale(1-opts.Beta1))
+		v = mat.Plus(v.Scale(opts.Beta2), mat.Dot(grad, grad).Scale(1-opts.Beta2))
+
+		mHat := m.Scale(1 / (1 - math.Pow(opts.Beta1, t)))
+		vHat := v.Scale(1 / (1 - math.Pow(opts.Beta2, t)))
+		update := mHat.Apply(func(i, j int) float64 {
+			return mHat.At(i, j) / (math.Sqrt(vHat.At(i, j)) + opts.Epsilon)
+		})
+
+		x = mat.Minus(x, update.Scale(opts.StepSize))
+		loss, grad = obj(x)
+	}
+	return Result{X: x, Loss: loss, Gradient: grad, Iterations: iter}
+}
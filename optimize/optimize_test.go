@@ -0,0 +1,65 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+
+	"github.com/campoy/mat"
+)
+
+// quadratic returns the ObjectiveFunc for f(theta) = ||theta - target||^2,
+// whose unique minimizer is target and whose gradient is 2*(theta-target).
+func quadratic(target mat.Matrix) ObjectiveFunc {
+	return func(theta mat.Matrix) (float64, mat.Matrix) {
+		diff := mat.Minus(theta, target)
+		loss := mat.Dot(diff, diff).Sum()
+		grad := diff.Scale(2)
+		return loss, grad
+	}
+}
+
+func closeTo(a, b mat.Matrix, tol float64) bool {
+	for i := 0; i < a.Rows(); i++ {
+		for j := 0; j < a.Cols(); j++ {
+			if math.Abs(a.At(i, j)-b.At(i, j)) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestSolvers(t *testing.T) {
+	target := mat.FromSlice(2, 1, []float64{3, -2})
+	init := mat.New(2, 1)
+
+	tt := []struct {
+		name   string
+		solver Solver
+		opts   Options
+	}{
+		{"GradientDescent", GradientDescent{}, Options{StepSize: 0.1, MaxIterations: 1000}},
+		{"Momentum", Momentum{}, Options{StepSize: 0.05, Momentum: 0.8, MaxIterations: 1000}},
+		{"Adam", Adam{}, Options{StepSize: 0.1, MaxIterations: 2000}},
+		{"LBFGS", LBFGS{}, Options{Linesearcher: NewBacktrackingArmijo(), StepSize: 1, MaxIterations: 100}},
+	}
+
+	for _, tc := range tt {
+		res := tc.solver.Minimize(quadratic(target), init, tc.opts)
+		if !closeTo(res.X, target, 1e-2) {
+			t.Errorf("%s: expected to converge to\n%v\ngot:\n%v\n", tc.name, target, res.X)
+		}
+	}
+}
+
+func TestBacktrackingArmijoRejectsAscent(t *testing.T) {
+	target := mat.FromSlice(1, 1, []float64{1})
+	obj := quadratic(target)
+	x := mat.New(1, 1)
+	loss, grad := obj(x)
+
+	ls := NewBacktrackingArmijo()
+	if step := ls.Search(obj, x, grad, grad, loss, 1); step != 0 {
+		t.Errorf("expected an ascent direction to be rejected, got step %v", step)
+	}
+}
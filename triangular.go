@@ -0,0 +1,190 @@
+package mat
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// TriangularMatrix is a square matrix stored in packed form: only the
+// n*(n+1)/2 elements above (or below) the diagonal are kept, halving the
+// storage of the equivalent Matrix.
+type TriangularMatrix struct {
+	n    int
+	uplo blas.Uplo
+	data []float64
+}
+
+// NewTriangular returns a new, zeroed TriangularMatrix of size n, storing
+// either the upper or lower triangle as given by uplo.
+func NewTriangular(n int, uplo blas.Uplo) TriangularMatrix {
+	return TriangularMatrix{n, uplo, make([]float64, n*(n+1)/2)}
+}
+
+// N returns the dimension of the triangular matrix.
+func (t TriangularMatrix) N() int { return t.n }
+
+// Uplo returns which triangle of the matrix is stored.
+func (t TriangularMatrix) Uplo() blas.Uplo { return t.uplo }
+
+// PackFromMatrix returns the TriangularMatrix obtained by keeping only the
+// triangle of m given by uplo.
+func PackFromMatrix(m Matrix, uplo blas.Uplo) TriangularMatrix {
+	if m.rows != m.cols {
+		panicf("can't pack a non-square %dx%d matrix into a triangular one", m.rows, m.cols)
+	}
+
+	t := NewTriangular(m.rows, uplo)
+	idx := 0
+	for i := 0; i < t.n; i++ {
+		for j := 0; j < t.n; j++ {
+			if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) {
+				continue
+			}
+			t.data[idx] = m.at(i, j)
+			idx++
+		}
+	}
+	return t
+}
+
+// Unpack returns the full, dense Matrix represented by t, with zeros in the
+// positions outside of the stored triangle.
+func (t TriangularMatrix) Unpack() Matrix {
+	m := New(t.n, t.n)
+	idx := 0
+	for i := 0; i < t.n; i++ {
+		for j := 0; j < t.n; j++ {
+			if (t.uplo == blas.Upper && j < i) || (t.uplo == blas.Lower && j > i) {
+				continue
+			}
+			m.set(i, j, t.data[idx])
+			idx++
+		}
+	}
+	return m
+}
+
+// SymmetricMatrix is a square symmetric matrix stored in packed form: only
+// the n*(n+1)/2 elements of one triangle are kept, since the other one can
+// always be recovered by symmetry.
+type SymmetricMatrix struct {
+	n    int
+	uplo blas.Uplo
+	data []float64
+}
+
+// NewSymmetric returns a new, zeroed SymmetricMatrix of size n.
+func NewSymmetric(n int) SymmetricMatrix {
+	return SymmetricMatrix{n, blas.Upper, make([]float64, n*(n+1)/2)}
+}
+
+// N returns the dimension of the symmetric matrix.
+func (s SymmetricMatrix) N() int { return s.n }
+
+// PackSymmetric returns the SymmetricMatrix obtained by keeping the upper
+// triangle of m. m must already be symmetric.
+func PackSymmetric(m Matrix) SymmetricMatrix {
+	if m.rows != m.cols {
+		panicf("can't pack a non-square %dx%d matrix into a symmetric one", m.rows, m.cols)
+	}
+
+	s := NewSymmetric(m.rows)
+	idx := 0
+	for i := 0; i < s.n; i++ {
+		for j := i; j < s.n; j++ {
+			s.data[idx] = m.at(i, j)
+			idx++
+		}
+	}
+	return s
+}
+
+// Unpack returns the full, dense Matrix represented by s.
+func (s SymmetricMatrix) Unpack() Matrix {
+	m := New(s.n, s.n)
+	idx := 0
+	for i := 0; i < s.n; i++ {
+		for j := i; j < s.n; j++ {
+			m.set(i, j, s.data[idx])
+			m.set(j, i, s.data[idx])
+			idx++
+		}
+	}
+	return m
+}
+
+func (s SymmetricMatrix) general() blas64.Symmetric {
+	g := s.Unpack()
+	return blas64.Symmetric{
+		Uplo:   s.uplo,
+		N:      s.n,
+		Stride: s.n,
+		Data:   g.data,
+	}
+}
+
+// SymmetricProduct returns the product a*b where a is symmetric, computed
+// with blas64.Spmv directly against the packed storage, column by column of
+// b, with no O(n^2) unpacking needed.
+func SymmetricProduct(a SymmetricMatrix, b Matrix) Matrix {
+	if a.n != b.rows {
+		panicf("can't compute product of matrices with dimensions %dx%d and %dx%d",
+			a.n, a.n, b.rows, b.cols)
+	}
+
+	ap := blas64.SymmetricPacked{Uplo: a.uplo, N: a.n, Data: a.data}
+	c := New(a.n, b.cols)
+	x, y := make([]float64, a.n), make([]float64, a.n)
+	for j := 0; j < b.cols; j++ {
+		for i := 0; i < a.n; i++ {
+			x[i] = b.at(i, j)
+		}
+		blas64.Spmv(1, ap, blas64.Vector{Inc: 1, Data: x}, 0, blas64.Vector{Inc: 1, Data: y})
+		for i := 0; i < a.n; i++ {
+			c.set(i, j, y[i])
+		}
+	}
+	return c
+}
+
+// TriangularVector returns t*x, computed in place on a copy of x with
+// blas64.Tpmv directly on the packed storage, with no unpacking needed.
+func TriangularVector(t TriangularMatrix, x []float64) []float64 {
+	if len(x) != t.n {
+		panicf("can't multiply a %dx%d triangular matrix by a vector of length %d", t.n, t.n, len(x))
+	}
+
+	y := make([]float64, len(x))
+	copy(y, x)
+	blas64.Tpmv(blas.NoTrans, blas64.TriangularPacked{
+		Uplo: t.uplo,
+		Diag: blas.NonUnit,
+		N:    t.n,
+		Data: t.data,
+	}, blas64.Vector{Inc: 1, Data: y})
+	return y
+}
+
+// TriangularSolve solves t*x = b for x, where t is triangular, via
+// back-/forward-substitution with blas64.Tpsv directly against the packed
+// storage, column by column of b, with no unpacking needed.
+func TriangularSolve(t TriangularMatrix, b Matrix) Matrix {
+	if t.n != b.rows {
+		panicf("can't solve a %dx%d triangular system against a %dx%d right-hand side",
+			t.n, t.n, b.rows, b.cols)
+	}
+
+	tp := blas64.TriangularPacked{Uplo: t.uplo, Diag: blas.NonUnit, N: t.n, Data: t.data}
+	x := b.Clone()
+	col := make([]float64, t.n)
+	for j := 0; j < b.cols; j++ {
+		for i := 0; i < t.n; i++ {
+			col[i] = x.at(i, j)
+		}
+		blas64.Tpsv(blas.NoTrans, tp, blas64.Vector{Inc: 1, Data: col})
+		for i := 0; i < t.n; i++ {
+			x.set(i, j, col[i])
+		}
+	}
+	return x
+}
@@ -0,0 +1,98 @@
+package mat
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestPackUnpackTriangular(t *testing.T) {
+	m := FromSlice(3, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	})
+
+	upper := PackFromMatrix(m, blas.Upper)
+	want := FromSlice(3, 3, []float64{
+		1, 2, 3,
+		0, 5, 6,
+		0, 0, 9,
+	})
+	if got := upper.Unpack(); !Equals(got, want) {
+		t.Errorf("expected:\n%v\ngot:\n%v\n", want, got)
+	}
+
+	lower := PackFromMatrix(m, blas.Lower)
+	want = FromSlice(3, 3, []float64{
+		1, 0, 0,
+		4, 5, 0,
+		7, 8, 9,
+	})
+	if got := lower.Unpack(); !Equals(got, want) {
+		t.Errorf("expected:\n%v\ngot:\n%v\n", want, got)
+	}
+}
+
+func TestPackUnpackSymmetric(t *testing.T) {
+	m := FromSlice(3, 3, []float64{
+		1, 2, 3,
+		2, 5, 6,
+		3, 6, 9,
+	})
+
+	s := PackSymmetric(m)
+	if got := s.Unpack(); !Equals(got, m) {
+		t.Errorf("expected:\n%v\ngot:\n%v\n", m, got)
+	}
+}
+
+func TestTriangularSolve(t *testing.T) {
+	u := PackFromMatrix(FromSlice(2, 2, []float64{
+		2, 1,
+		0, 2,
+	}), blas.Upper)
+	b := FromSlice(2, 1, []float64{4, 2})
+
+	x := TriangularSolve(u, b)
+	want := FromSlice(2, 1, []float64{1.5, 1})
+	if !Equals(x, want) {
+		t.Errorf("expected:\n%v\ngot:\n%v\n", want, x)
+	}
+}
+
+func TestSymmetricProduct(t *testing.T) {
+	a := PackSymmetric(FromSlice(2, 2, []float64{
+		2, 1,
+		1, 3,
+	}))
+	b := FromSlice(2, 2, []float64{
+		1, 2,
+		3, 4,
+	})
+
+	got := SymmetricProduct(a, b)
+	want := FromSlice(2, 2, []float64{
+		5, 8,
+		10, 14,
+	})
+	if !Equals(got, want) {
+		t.Errorf("expected:\n%v\ngot:\n%v\n", want, got)
+	}
+}
+
+func TestTriangularVector(t *testing.T) {
+	u := PackFromMatrix(FromSlice(2, 2, []float64{
+		2, 1,
+		0, 3,
+	}), blas.Upper)
+
+	got := TriangularVector(u, []float64{1, 2})
+	want := []float64{4, 6}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected:\n%v\ngot:\n%v\n", want, got)
+			break
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package mat
+
+import "testing"
+
+func TestSliceColsIsView(t *testing.T) {
+	m := ConcatenateCols(
+		New(3, 1).AddScalar(1),
+		New(3, 2).AddScalar(2),
+		New(3, 3).AddScalar(3))
+
+	view := m.SliceCols(1, 3)
+	if got, want := view.Rows(), 3; got != want {
+		t.Errorf("expected %d rows, got %d", want, got)
+	}
+	if got, want := view.Cols(), 2; got != want {
+		t.Errorf("expected %d cols, got %d", want, got)
+	}
+
+	// Writing to the view must not change m: views are copy-on-write.
+	view.Set(0, 0, 42)
+	if got, want := m.At(0, 1), 2.0; got != want {
+		t.Errorf("expected slicing not to mutate the original matrix, got %v instead of %v", got, want)
+	}
+}
+
+func TestSliceRowsIsView(t *testing.T) {
+	m := ConcatenateRows(
+		New(1, 3).AddScalar(1),
+		New(2, 3).AddScalar(2),
+		New(3, 3).AddScalar(3))
+
+	view := m.SliceRows(1, 3)
+	want := New(2, 3).AddScalar(2)
+	if !Equals(view, want) {
+		t.Errorf("expected:\n%v\ngot:\n%v\n", want, view)
+	}
+
+	view.Set(0, 0, 42)
+	if got, want := m.At(1, 0), 2.0; got != want {
+		t.Errorf("expected slicing not to mutate the original matrix, got %v instead of %v", got, want)
+	}
+}
+
+func TestTransposeIsView(t *testing.T) {
+	m := FromSlice(2, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+	})
+
+	tr := m.T()
+	want := FromSlice(3, 2, []float64{
+		1, 4,
+		2, 5,
+		3, 6,
+	})
+	if !Equals(tr, want) {
+		t.Errorf("expected:\n%v\ngot:\n%v\n", want, tr)
+	}
+
+	tr.Set(0, 0, 42)
+	if got, want := m.At(0, 0), 1.0; got != want {
+		t.Errorf("expected transposing not to mutate the original matrix, got %v instead of %v", got, want)
+	}
+}
+
+func TestSliceOfTransposeIsView(t *testing.T) {
+	m := FromSlice(3, 2, []float64{
+		1, 2,
+		3, 4,
+		5, 6,
+	})
+
+	view := m.T().SliceCols(1, 3)
+	want := FromSlice(2, 2, []float64{
+		3, 5,
+		4, 6,
+	})
+	if !Equals(view, want) {
+		t.Errorf("expected:\n%v\ngot:\n%v\n", want, view)
+	}
+}
+
+func TestMaterialize(t *testing.T) {
+	m := ConcatenateCols(New(2, 1).AddScalar(1), New(2, 2).AddScalar(2))
+	view := m.SliceCols(1, 3)
+
+	full := view.Materialize()
+	if !Equals(full, view) {
+		t.Errorf("expected Materialize to preserve values, want:\n%v\ngot:\n%v\n", view, full)
+	}
+
+	full.Set(0, 0, 42)
+	if got, want := view.At(0, 0), 2.0; got != want {
+		t.Errorf("expected materializing to copy, got %v instead of %v", got, want)
+	}
+}
+
+func TestApply(t *testing.T) {
+	m := New(2, 2)
+	r := m.Apply(func(i, j int) float64 { return float64(i + j) })
+
+	want := FromSlice(2, 2, []float64{
+		0, 1,
+		1, 2,
+	})
+	if !Equals(r, want) {
+		t.Errorf("expected:\n%v\ngot:\n%v\n", want, r)
+	}
+	if got, want := m.At(0, 0), 0.0; got != want {
+		t.Errorf("expected Apply not to mutate the original matrix, got %v instead of %v", got, want)
+	}
+}